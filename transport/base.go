@@ -1,8 +1,12 @@
 package transport
 
 import (
+	"context"
 	"errors"
+	"os"
 	"time"
+
+	"golang.org/x/crypto/ssh"
 )
 
 // constants for basic transport values.
@@ -24,11 +28,27 @@ var ErrTransportTimeout = errors.New("transport operation timed out")
 // ErrKeyVerificationFailed ssh key verification failure.
 var ErrKeyVerificationFailed = errors.New("ssh key verification failed")
 
+// ErrInvalidTransportArgs error for a transport constructed with insufficient arguments.
+var ErrInvalidTransportArgs = errors.New("invalid transport arguments provided")
+
+// ErrTransportNotOpen error for an operation that requires an established connection (such as a
+// FileTransport transfer) attempted before Open/OpenNetconf has been called.
+var ErrTransportNotOpen = errors.New("transport is not open")
+
 // BaseTransportArgs struct for attributes that are required for any transport type.
 type BaseTransportArgs struct {
 	Host         string
 	Port         int
 	AuthUsername string
+	PtyHeight    int
+	PtyWidth     int
+	// HostKeyCallback, when set, replaces the Standard transport's default host key verification
+	// (see BuildHostKeyCallback for the known_hosts backed verifier scrapligo ships). The System
+	// transport runs it only when AuthStrictKey is set, as a check made before ssh is spawned; the
+	// ssh subprocess still applies its own known_hosts checking afterwards.
+	HostKeyCallback ssh.HostKeyCallback
+	// ProxyJump is an ordered list of bastion/jump hosts to hop through before reaching Host.
+	ProxyJump []ProxyHop
 	// passed as pointers so they can be modified at the driver layer
 	TimeoutSocket    *time.Duration
 	TimeoutTransport *time.Duration
@@ -36,9 +56,14 @@ type BaseTransportArgs struct {
 	// TransportOptions; not sure how to handle this yet as it is very... vague and... this is go... :)
 }
 
-type transportResult struct {
-	result []byte
-	error  error
+// ProxyHop represents a single ssh bastion/jump host hop that must be traversed before reaching
+// the final target host.
+type ProxyHop struct {
+	Host           string
+	Port           int
+	AuthUsername   string
+	AuthPassword   string
+	AuthPrivateKey string
 }
 
 // BaseTransport interface defining required methods for any transport type.
@@ -52,24 +77,22 @@ type BaseTransport interface {
 	FormatLogMessage(string, string) string
 }
 
-func transportTimeout(
-	timeout time.Duration,
-	f func() *transportResult,
-) ([]byte, error) {
-	c := make(chan *transportResult, 1)
-
-	go func() {
-		r := f()
-		c <- r
-		close(c)
-	}()
-
-	timer := time.NewTimer(timeout)
+// ContextTransport is implemented by transports that support canceling in-flight operations via
+// context.Context rather than an internal fire-and-forget timeout. The context-less methods on
+// BaseTransport are thin wrappers around these, built from context.WithTimeout(*TimeoutTransport),
+// so existing callers keep working unchanged.
+type ContextTransport interface {
+	OpenCtx(ctx context.Context) error
+	OpenNetconfCtx(ctx context.Context) error
+	ReadCtx(ctx context.Context) ([]byte, error)
+	ReadNCtx(ctx context.Context, n int) ([]byte, error)
+	WriteCtx(ctx context.Context, channelInput []byte) error
+}
 
-	select {
-	case r := <-c:
-		return r.result, r.error
-	case <-timer.C:
-		return make([]byte, 0), ErrTransportTimeout
-	}
+// FileTransport is an optional interface a transport may implement to support copying files
+// to/from the remote host directly, so drivers can offer file transfer helpers without the
+// caller having to open a second ssh connection by hand.
+type FileTransport interface {
+	PutFile(localPath, remotePath string, mode os.FileMode) error
+	GetFile(remotePath, localPath string) error
 }
\ No newline at end of file
@@ -0,0 +1,260 @@
+// +build !windows
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/scrapli/scrapligo/logging"
+
+	"github.com/creack/pty"
+)
+
+// constants for command transport values.
+const (
+	CommandTransportName      = "command"
+	DockerTransportName       = "docker"
+	KubectlTransportName      = "kubectl"
+	ContainerlabTransportName = "containerlab"
+)
+
+// CommandTransport is a BaseTransport implementation that spawns an arbitrary argv under a PTY
+// with no ssh flags injected. System.ExecCmd already hints at running `docker exec` or
+// `kubectl exec` in place of `ssh`, but still forces ssh-shaped arguments through buildOpenCmd;
+// CommandTransport is the structured home for exec-style backends that have no ssh connection
+// underneath them at all, such as containers and virtualized lab nodes.
+type CommandTransport struct {
+	BaseTransportArgs    *BaseTransportArgs
+	CommandTransportArgs *CommandTransportArgs
+	fileObj              *os.File
+}
+
+// CommandTransportArgs struct representing attributes required for the CommandTransport. Argv is
+// the full command, including its binary, to spawn under a PTY in place of `ssh`.
+type CommandTransportArgs struct {
+	Argv []string
+}
+
+// NewDockerCommandTransport builds a CommandTransport that attaches to a running container via
+// `docker exec -it <container> <shell>`. shell defaults to "sh" if not provided.
+func NewDockerCommandTransport(
+	baseArgs *BaseTransportArgs,
+	container, shell string,
+) *CommandTransport {
+	if shell == "" {
+		shell = "sh"
+	}
+
+	return &CommandTransport{
+		BaseTransportArgs: baseArgs,
+		CommandTransportArgs: &CommandTransportArgs{
+			Argv: []string{"docker", "exec", "-it", container, shell},
+		},
+	}
+}
+
+// NewKubectlCommandTransport builds a CommandTransport that attaches to a pod via
+// `kubectl exec -it -n <namespace> <pod> -- <shell>`. shell defaults to "sh" if not provided.
+func NewKubectlCommandTransport(
+	baseArgs *BaseTransportArgs,
+	namespace, pod, shell string,
+) *CommandTransport {
+	if shell == "" {
+		shell = "sh"
+	}
+
+	return &CommandTransport{
+		BaseTransportArgs: baseArgs,
+		CommandTransportArgs: &CommandTransportArgs{
+			Argv: []string{"kubectl", "exec", "-it", "-n", namespace, pod, "--", shell},
+		},
+	}
+}
+
+// NewContainerlabCommandTransport builds a CommandTransport that attaches to a containerlab node
+// via `docker exec -it clab-<topology>-<node> <shell>`, matching the container naming
+// containerlab itself generates for its nodes.
+func NewContainerlabCommandTransport(
+	baseArgs *BaseTransportArgs,
+	topology, node, shell string,
+) *CommandTransport {
+	return NewDockerCommandTransport(baseArgs, fmt.Sprintf("clab-%s-%s", topology, node), shell)
+}
+
+// OpenCtx spawns CommandTransportArgs.Argv under a PTY, tearing the process back down if ctx is
+// done before it finishes starting.
+func (t *CommandTransport) OpenCtx(ctx context.Context) error {
+	if len(t.CommandTransportArgs.Argv) == 0 {
+		return ErrInvalidTransportArgs
+	}
+
+	logging.LogDebug(
+		FormatLogMessage(t.BaseTransportArgs,
+			"debug",
+			fmt.Sprintf(
+				"\"attempting to open command transport with the following argv: %s",
+				t.CommandTransportArgs.Argv,
+			),
+		),
+	)
+
+	command := exec.Command( //nolint:gosec
+		t.CommandTransportArgs.Argv[0],
+		t.CommandTransportArgs.Argv[1:]...,
+	)
+
+	fileObj, err := pty.StartWithSize(
+		command,
+		&pty.Winsize{
+			Rows: uint16(t.BaseTransportArgs.PtyHeight),
+			Cols: uint16(t.BaseTransportArgs.PtyWidth),
+		},
+	)
+
+	if err != nil {
+		logging.LogError(
+			FormatLogMessage(t.BaseTransportArgs, "error", "failed opening command transport"),
+		)
+
+		return err
+	}
+
+	t.fileObj = fileObj
+
+	if ctx.Err() != nil {
+		_ = t.Close()
+
+		return ctx.Err()
+	}
+
+	logging.LogDebug(
+		FormatLogMessage(t.BaseTransportArgs, "debug", "command transport opened"),
+	)
+
+	return nil
+}
+
+// Open spawns CommandTransportArgs.Argv under a PTY.
+func (t *CommandTransport) Open() error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.OpenCtx(ctx)
+}
+
+// OpenNetconfCtx is not meaningful for a bare command exec -- there is no ssh subsystem to
+// request -- so it simply opens the same PTY-backed argv as OpenCtx.
+func (t *CommandTransport) OpenNetconfCtx(ctx context.Context) error {
+	return t.OpenCtx(ctx)
+}
+
+// OpenNetconf is not meaningful for a bare command exec -- there is no ssh subsystem to request
+// -- so it simply opens the same PTY-backed argv as Open.
+func (t *CommandTransport) OpenNetconf() error {
+	return t.Open()
+}
+
+// Close closes the transport connection to the command's PTY.
+func (t *CommandTransport) Close() error {
+	err := t.fileObj.Close()
+	t.fileObj = nil
+
+	logging.LogDebug(
+		FormatLogMessage(t.BaseTransportArgs, "debug", "command transport closed"),
+	)
+
+	return err
+}
+
+func (t *CommandTransport) readNCtx(ctx context.Context, n int) ([]byte, error) {
+	deadline, hasDeadline := ctx.Deadline()
+
+	deadlineSupported := false
+
+	if hasDeadline {
+		deadlineSupported = t.fileObj.SetReadDeadline(deadline) == nil
+	}
+
+	if !deadlineSupported {
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-ctx.Done():
+				_ = t.fileObj.Close()
+			case <-done:
+			}
+		}()
+	}
+
+	b := make([]byte, n)
+	nRead, err := t.fileObj.Read(b)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			logging.LogError(
+				FormatLogMessage(t.BaseTransportArgs, "error", "timed out reading from transport"),
+			)
+
+			return nil, ErrTransportTimeout
+		}
+
+		return nil, ErrTransportFailure
+	}
+
+	return b[:nRead], nil
+}
+
+// ReadCtx reads bytes from the transport, subject to ctx-based cancellation.
+func (t *CommandTransport) ReadCtx(ctx context.Context) ([]byte, error) {
+	return t.readNCtx(ctx, ReadSize)
+}
+
+// Read reads bytes from the transport.
+func (t *CommandTransport) Read() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.ReadCtx(ctx)
+}
+
+// ReadNCtx reads n bytes from the transport, subject to ctx-based cancellation.
+func (t *CommandTransport) ReadNCtx(ctx context.Context, n int) ([]byte, error) {
+	return t.readNCtx(ctx, n)
+}
+
+// ReadN reads N bytes from the transport.
+func (t *CommandTransport) ReadN(n int) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.ReadNCtx(ctx, n)
+}
+
+// WriteCtx writes bytes to the transport, subject to ctx-based cancellation.
+func (t *CommandTransport) WriteCtx(ctx context.Context, channelInput []byte) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = t.fileObj.SetWriteDeadline(deadline)
+	}
+
+	_, err := t.fileObj.Write(channelInput)
+
+	return err
+}
+
+// Write writes bytes to the transport.
+func (t *CommandTransport) Write(channelInput []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.WriteCtx(ctx, channelInput)
+}
+
+// IsAlive indicates if the transport is alive or not.
+func (t *CommandTransport) IsAlive() bool {
+	return t.fileObj != nil
+}
@@ -0,0 +1,58 @@
+// +build !windows
+
+package transport
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewDockerCommandTransportDefaultsShell(t *testing.T) {
+	ct := NewDockerCommandTransport(&BaseTransportArgs{}, "mycontainer", "")
+
+	want := []string{"docker", "exec", "-it", "mycontainer", "sh"}
+	if !reflect.DeepEqual(ct.CommandTransportArgs.Argv, want) {
+		t.Fatalf("expected argv %v, got %v", want, ct.CommandTransportArgs.Argv)
+	}
+}
+
+func TestNewDockerCommandTransportCustomShell(t *testing.T) {
+	ct := NewDockerCommandTransport(&BaseTransportArgs{}, "mycontainer", "bash")
+
+	want := []string{"docker", "exec", "-it", "mycontainer", "bash"}
+	if !reflect.DeepEqual(ct.CommandTransportArgs.Argv, want) {
+		t.Fatalf("expected argv %v, got %v", want, ct.CommandTransportArgs.Argv)
+	}
+}
+
+func TestNewKubectlCommandTransportDefaultsShell(t *testing.T) {
+	ct := NewKubectlCommandTransport(&BaseTransportArgs{}, "prod", "mypod", "")
+
+	want := []string{"kubectl", "exec", "-it", "-n", "prod", "mypod", "--", "sh"}
+	if !reflect.DeepEqual(ct.CommandTransportArgs.Argv, want) {
+		t.Fatalf("expected argv %v, got %v", want, ct.CommandTransportArgs.Argv)
+	}
+}
+
+func TestNewContainerlabCommandTransportBuildsClabContainerName(t *testing.T) {
+	ct := NewContainerlabCommandTransport(&BaseTransportArgs{}, "mytopo", "node1", "")
+
+	want := []string{"docker", "exec", "-it", "clab-mytopo-node1", "sh"}
+	if !reflect.DeepEqual(ct.CommandTransportArgs.Argv, want) {
+		t.Fatalf("expected argv %v, got %v", want, ct.CommandTransportArgs.Argv)
+	}
+}
+
+func TestCommandTransportOpenCtxRejectsEmptyArgv(t *testing.T) {
+	transportTimeout := time.Second
+
+	ct := &CommandTransport{
+		BaseTransportArgs:    &BaseTransportArgs{TimeoutTransport: &transportTimeout},
+		CommandTransportArgs: &CommandTransportArgs{},
+	}
+
+	if err := ct.Open(); err != ErrInvalidTransportArgs {
+		t.Fatalf("expected ErrInvalidTransportArgs, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// BuildHostKeyCallback returns an ssh.HostKeyCallback that validates a remote host key against
+// the entries in knownHostsFile. When knownHostsFile is empty it defaults to
+// "~/.ssh/known_hosts". Verification failures are reported as ErrKeyVerificationFailed with the
+// offending key's fingerprint included so the mismatch can be diagnosed without re-running with
+// increased verbosity.
+func BuildHostKeyCallback(knownHostsFile string) (ssh.HostKeyCallback, error) {
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if verifyErr := callback(hostname, remote, key); verifyErr != nil {
+			return fmt.Errorf(
+				"%w: %s %s",
+				ErrKeyVerificationFailed,
+				key.Type(),
+				ssh.FingerprintSHA256(key),
+			)
+		}
+
+		return nil
+	}, nil
+}
@@ -0,0 +1,77 @@
+package transport
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func writeKnownHostsFixture(t *testing.T, host string, key ssh.PublicKey) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "known_hosts")
+
+	line := knownhosts.Line([]string{host}, key)
+	if err := os.WriteFile(path, []byte(line+"\n"), 0o600); err != nil {
+		t.Fatalf("failed writing known_hosts fixture: %v", err)
+	}
+
+	return path
+}
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed generating test key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatalf("failed building signer from test key: %v", err)
+	}
+
+	return signer
+}
+
+func TestBuildHostKeyCallbackAcceptsMatchingKey(t *testing.T) {
+	signer := newTestSigner(t)
+	knownHostsPath := writeKnownHostsFixture(t, "example.com:22", signer.PublicKey())
+
+	callback, err := BuildHostKeyCallback(knownHostsPath)
+	if err != nil {
+		t.Fatalf("unexpected error building callback: %v", err)
+	}
+
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 22}
+
+	if err := callback("example.com:22", addr, signer.PublicKey()); err != nil {
+		t.Fatalf("expected matching host key to be accepted, got: %v", err)
+	}
+}
+
+func TestBuildHostKeyCallbackRejectsMismatchedKey(t *testing.T) {
+	signer := newTestSigner(t)
+	knownHostsPath := writeKnownHostsFixture(t, "example.com:22", signer.PublicKey())
+
+	callback, err := BuildHostKeyCallback(knownHostsPath)
+	if err != nil {
+		t.Fatalf("unexpected error building callback: %v", err)
+	}
+
+	other := newTestSigner(t)
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 22}
+
+	err = callback("example.com:22", addr, other.PublicKey())
+	if !errors.Is(err, ErrKeyVerificationFailed) {
+		t.Fatalf("expected ErrKeyVerificationFailed, got: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+// +build !windows
+
+package transport
+
+import "testing"
+
+func TestBuildProxyJumpArg(t *testing.T) {
+	hops := []ProxyHop{
+		{Host: "bastion1", Port: 22, AuthUsername: "alice"},
+		{Host: "bastion2", Port: 2222, AuthUsername: "bob"},
+	}
+
+	got := buildProxyJumpArg(hops)
+	want := "alice@bastion1:22,bob@bastion2:2222"
+
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestBuildProxyJumpArgSingleHop(t *testing.T) {
+	hops := []ProxyHop{
+		{Host: "bastion1", Port: 22, AuthUsername: "alice"},
+	}
+
+	got := buildProxyJumpArg(hops)
+	want := "alice@bastion1:22"
+
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
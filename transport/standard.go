@@ -0,0 +1,536 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/scrapli/scrapligo/logging"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// Standard is the native (pure golang.org/x/crypto/ssh) transport option for scrapligo. Unlike
+// System it does not fork an external `ssh` process, so it has no dependency on an `ssh` binary
+// being present on the host and works on platforms (such as Windows) where System is unavailable.
+type Standard struct {
+	BaseTransportArgs     *BaseTransportArgs
+	StandardTransportArgs *StandardTransportArgs
+	client                *ssh.Client
+	session               *ssh.Session
+	stdin                 io.WriteCloser
+	stdout                io.Reader
+}
+
+// StandardTransportArgs struct representing attributes required for the Standard transport.
+type StandardTransportArgs struct {
+	AuthPassword      string
+	AuthPrivateKey    string
+	AuthStrictKey     bool
+	SSHKnownHostsFile string
+}
+
+func (t *Standard) authMethods() ([]ssh.AuthMethod, error) {
+	var authMethods []ssh.AuthMethod
+
+	if t.StandardTransportArgs.AuthPrivateKey != "" {
+		key, err := os.ReadFile(t.StandardTransportArgs.AuthPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err == nil {
+			authMethods = append(authMethods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	if t.StandardTransportArgs.AuthPassword != "" {
+		authMethods = append(authMethods, ssh.Password(t.StandardTransportArgs.AuthPassword))
+	}
+
+	return authMethods, nil
+}
+
+func (t *Standard) clientConfig() (*ssh.ClientConfig, error) {
+	authMethods, err := t.authMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := t.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:            t.BaseTransportArgs.AuthUsername,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         *t.BaseTransportArgs.TimeoutSocket,
+	}, nil
+}
+
+func (t *Standard) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	if t.BaseTransportArgs.HostKeyCallback != nil {
+		return t.BaseTransportArgs.HostKeyCallback, nil
+	}
+
+	if !t.StandardTransportArgs.AuthStrictKey {
+		return ssh.InsecureIgnoreHostKey(), nil //nolint:gosec
+	}
+
+	return BuildHostKeyCallback(t.StandardTransportArgs.SSHKnownHostsFile)
+}
+
+func (t *Standard) dial() (*ssh.Client, error) {
+	if len(t.BaseTransportArgs.ProxyJump) > 0 {
+		return t.dialWithProxyJump()
+	}
+
+	config, err := t.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	addr := fmt.Sprintf("%s:%d", t.BaseTransportArgs.Host, t.BaseTransportArgs.Port)
+
+	return ssh.Dial("tcp", addr, config)
+}
+
+// dialWithProxyJump dials each configured ProxyHop in sequence, tunnelling the connection to the
+// next hop (and, finally, to BaseTransportArgs.Host) through the previous hop's ssh.Client.
+func (t *Standard) dialWithProxyJump() (*ssh.Client, error) {
+	hops := t.BaseTransportArgs.ProxyJump
+
+	firstConfig, err := t.proxyHopClientConfig(hops[0])
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%d", hops[0].Host, hops[0].Port), firstConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hop := range hops[1:] {
+		config, hopErr := t.proxyHopClientConfig(hop)
+		if hopErr != nil {
+			_ = client.Close()
+
+			return nil, hopErr
+		}
+
+		nextClient, dialErr := dialThroughClient(client, fmt.Sprintf("%s:%d", hop.Host, hop.Port), config)
+		if dialErr != nil {
+			_ = client.Close()
+
+			return nil, dialErr
+		}
+
+		client = nextClient
+	}
+
+	finalConfig, err := t.clientConfig()
+	if err != nil {
+		_ = client.Close()
+
+		return nil, err
+	}
+
+	finalClient, err := dialThroughClient(
+		client,
+		fmt.Sprintf("%s:%d", t.BaseTransportArgs.Host, t.BaseTransportArgs.Port),
+		finalConfig,
+	)
+	if err != nil {
+		_ = client.Close()
+
+		return nil, err
+	}
+
+	return finalClient, nil
+}
+
+// dialThroughClient opens a tcp connection to addr through via (an already established ssh
+// client) and upgrades it to its own ssh.Client, allowing hops to be chained arbitrarily deep.
+func dialThroughClient(via *ssh.Client, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := via.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.NewClient(clientConn, chans, reqs), nil
+}
+
+// proxyHopClientConfig builds the ssh.ClientConfig used to authenticate to a single ProxyHop.
+// Host key verification uses the same BaseTransportArgs.HostKeyCallback/strict-key configuration
+// as the final target host -- an intermediate bastion is just as reachable to a MITM as the
+// target, so it gets no less scrutiny.
+func (t *Standard) proxyHopClientConfig(hop ProxyHop) (*ssh.ClientConfig, error) {
+	var authMethods []ssh.AuthMethod
+
+	if hop.AuthPrivateKey != "" {
+		key, err := os.ReadFile(hop.AuthPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+
+	if hop.AuthPassword != "" {
+		authMethods = append(authMethods, ssh.Password(hop.AuthPassword))
+	}
+
+	hostKeyCallback, err := t.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:            hop.AuthUsername,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         *t.BaseTransportArgs.TimeoutSocket,
+	}, nil
+}
+
+func (t *Standard) openSession() (*ssh.Session, error) {
+	client, err := t.dial()
+	if err != nil {
+		logging.LogError(
+			FormatLogMessage(
+				t.BaseTransportArgs,
+				"error",
+				"failed opening transport connection to host",
+			),
+		)
+
+		return nil, err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		client.Close()
+
+		return nil, err
+	}
+
+	t.client = client
+
+	return session, nil
+}
+
+// OpenCtx opens a standard (native golang ssh) connection to the device and requests a PTY for
+// interactive shell use, tearing the session back down if ctx is done before it finishes
+// establishing.
+func (t *Standard) OpenCtx(ctx context.Context) error {
+	session, err := t.openSession()
+	if err != nil {
+		return err
+	}
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+
+	err = session.RequestPty("xterm", t.BaseTransportArgs.PtyHeight, t.BaseTransportArgs.PtyWidth, modes)
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	err = session.Shell()
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	t.session = session
+	t.stdin = stdin
+	t.stdout = stdout
+
+	if ctx.Err() != nil {
+		_ = t.Close()
+
+		return ctx.Err()
+	}
+
+	logging.LogDebug(
+		FormatLogMessage(t.BaseTransportArgs, "debug", "transport connection to host opened"),
+	)
+
+	return nil
+}
+
+// Open opens a standard (native golang ssh) connection to the device and requests a PTY for
+// interactive shell use.
+func (t *Standard) Open() error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.OpenCtx(ctx)
+}
+
+// OpenNetconfCtx opens a netconf connection by requesting the "netconf" ssh subsystem rather than
+// starting an interactive shell, subject to the same ctx-based cancellation as OpenCtx.
+func (t *Standard) OpenNetconfCtx(ctx context.Context) error {
+	session, err := t.openSession()
+	if err != nil {
+		return err
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	err = session.RequestSubsystem("netconf")
+	if err != nil {
+		session.Close()
+
+		return err
+	}
+
+	t.session = session
+	t.stdin = stdin
+	t.stdout = stdout
+
+	if ctx.Err() != nil {
+		_ = t.Close()
+
+		return ctx.Err()
+	}
+
+	logging.LogDebug(
+		FormatLogMessage(t.BaseTransportArgs, "debug", "netconf transport connection to host opened"),
+	)
+
+	return nil
+}
+
+// OpenNetconf opens a netconf connection by requesting the "netconf" ssh subsystem rather than
+// starting an interactive shell.
+func (t *Standard) OpenNetconf() error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.OpenNetconfCtx(ctx)
+}
+
+// Close closes the transport connection to the device.
+func (t *Standard) Close() error {
+	err := t.session.Close()
+
+	if t.client != nil {
+		_ = t.client.Close()
+	}
+
+	t.session = nil
+	t.client = nil
+
+	logging.LogDebug(
+		FormatLogMessage(t.BaseTransportArgs, "debug", "transport connection to host closed"),
+	)
+
+	return err
+}
+
+type standardReadResult struct {
+	b   []byte
+	err error
+}
+
+// readNCtx reads n bytes from the transport, returning ErrTransportTimeout once ctx is done.
+// ssh.Session has no read-deadline equivalent to System's pty file, so cancellation works by
+// closing the session outright, which unblocks the in-flight stdout.Read below with an error.
+func (t *Standard) readNCtx(ctx context.Context, n int) ([]byte, error) {
+	c := make(chan standardReadResult, 1)
+
+	go func() {
+		b := make([]byte, n)
+
+		nRead, err := t.stdout.Read(b)
+		if err != nil {
+			c <- standardReadResult{err: ErrTransportFailure}
+
+			return
+		}
+
+		c <- standardReadResult{b: b[:nRead]}
+	}()
+
+	select {
+	case r := <-c:
+		return r.b, r.err
+	case <-ctx.Done():
+		_ = t.session.Close()
+
+		logging.LogError(
+			FormatLogMessage(t.BaseTransportArgs, "error", "timed out reading from transport"),
+		)
+
+		return nil, ErrTransportTimeout
+	}
+}
+
+// ReadCtx reads bytes from the transport, subject to ctx-based cancellation.
+func (t *Standard) ReadCtx(ctx context.Context) ([]byte, error) {
+	return t.readNCtx(ctx, ReadSize)
+}
+
+// Read reads bytes from the transport.
+func (t *Standard) Read() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.ReadCtx(ctx)
+}
+
+// ReadNCtx reads n bytes from the transport, subject to ctx-based cancellation.
+func (t *Standard) ReadNCtx(ctx context.Context, n int) ([]byte, error) {
+	return t.readNCtx(ctx, n)
+}
+
+// ReadN reads N bytes from the transport.
+func (t *Standard) ReadN(n int) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.ReadNCtx(ctx, n)
+}
+
+// WriteCtx writes bytes to the transport. ctx is accepted for interface symmetry with the other
+// context-aware methods; writes to the stdin pipe do not block the way reads can, so there is
+// nothing additional to cancel.
+func (t *Standard) WriteCtx(_ context.Context, channelInput []byte) error {
+	_, err := t.stdin.Write(channelInput)
+
+	return err
+}
+
+// Write writes bytes to the transport.
+func (t *Standard) Write(channelInput []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.WriteCtx(ctx, channelInput)
+}
+
+// IsAlive indicates if the transport is alive or not.
+func (t *Standard) IsAlive() bool {
+	return t.session != nil
+}
+
+// PutFile copies the local file at localPath to remotePath on the device over sftp, reusing the
+// existing ssh connection rather than opening a second one.
+func (t *Standard) PutFile(localPath, remotePath string, mode os.FileMode) error {
+	if t.client == nil {
+		return ErrTransportNotOpen
+	}
+
+	sftpClient, err := sftp.NewClient(t.client)
+	if err != nil {
+		return err
+	}
+	defer sftpClient.Close()
+
+	local, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	remote, err := sftpClient.Create(remotePath)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	if _, err := io.Copy(remote, local); err != nil {
+		return err
+	}
+
+	return remote.Chmod(mode)
+}
+
+// GetFile copies remotePath on the device to the local file at localPath over sftp, reusing the
+// existing ssh connection rather than opening a second one.
+func (t *Standard) GetFile(remotePath, localPath string) error {
+	if t.client == nil {
+		return ErrTransportNotOpen
+	}
+
+	sftpClient, err := sftp.NewClient(t.client)
+	if err != nil {
+		return err
+	}
+	defer sftpClient.Close()
+
+	remote, err := sftpClient.Open(remotePath)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	local, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	_, err = io.Copy(local, remote)
+
+	return err
+}
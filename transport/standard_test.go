@@ -0,0 +1,78 @@
+// +build !windows
+
+package transport
+
+import (
+	"net"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestStandardHostKeyCallbackPrefersOverride(t *testing.T) {
+	called := false
+
+	std := &Standard{
+		BaseTransportArgs: &BaseTransportArgs{
+			HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+				called = true
+
+				return nil
+			},
+		},
+		StandardTransportArgs: &StandardTransportArgs{AuthStrictKey: true},
+	}
+
+	callback, err := std.hostKeyCallback()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := callback("host", nil, nil); err != nil {
+		t.Fatalf("unexpected error invoking callback: %v", err)
+	}
+
+	if !called {
+		t.Fatal("expected override callback to be invoked")
+	}
+}
+
+func TestStandardHostKeyCallbackInsecureWhenNotStrict(t *testing.T) {
+	std := &Standard{
+		BaseTransportArgs:     &BaseTransportArgs{},
+		StandardTransportArgs: &StandardTransportArgs{AuthStrictKey: false},
+	}
+
+	callback, err := std.hostKeyCallback()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := callback("host", nil, nil); err != nil {
+		t.Fatalf("expected insecure callback to accept any key, got: %v", err)
+	}
+}
+
+func TestStandardHostKeyCallbackUsesKnownHostsWhenStrict(t *testing.T) {
+	signer := newTestSigner(t)
+	knownHostsPath := writeKnownHostsFixture(t, "example.com:22", signer.PublicKey())
+
+	std := &Standard{
+		BaseTransportArgs: &BaseTransportArgs{},
+		StandardTransportArgs: &StandardTransportArgs{
+			AuthStrictKey:     true,
+			SSHKnownHostsFile: knownHostsPath,
+		},
+	}
+
+	callback, err := std.hostKeyCallback()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 22}
+
+	if err := callback("example.com:22", addr, signer.PublicKey()); err != nil {
+		t.Fatalf("expected matching host key to be accepted, got: %v", err)
+	}
+}
@@ -3,13 +3,19 @@
 package transport
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/scrapli/scrapligo/logging"
 
 	"github.com/creack/pty"
+	"golang.org/x/crypto/ssh"
 )
 
 // System the "system" (pty subprocess wrapper) transport option for scrapligo.
@@ -29,6 +35,18 @@ type SystemTransportArgs struct {
 	SSHKnownHostsFile string
 }
 
+// buildProxyJumpArg renders a ProxyHop chain as the comma separated "user@host:port" list that
+// ssh's `-J` flag expects.
+func buildProxyJumpArg(hops []ProxyHop) string {
+	rendered := make([]string, 0, len(hops))
+
+	for _, hop := range hops {
+		rendered = append(rendered, fmt.Sprintf("%s@%s:%d", hop.AuthUsername, hop.Host, hop.Port))
+	}
+
+	return strings.Join(rendered, ",")
+}
+
 func (t *System) buildOpenCmd() {
 	// base open command arguments; the exec command itself will be passed in Open()
 	// need to add user arguments could go here at some point
@@ -43,6 +61,14 @@ func (t *System) buildOpenCmd() {
 		fmt.Sprintf("ServerAliveInterval=%d", int(t.BaseTransportArgs.TimeoutTransport.Seconds())),
 	)
 
+	if len(t.BaseTransportArgs.ProxyJump) > 0 {
+		t.OpenCmd = append(
+			t.OpenCmd,
+			"-J",
+			buildProxyJumpArg(t.BaseTransportArgs.ProxyJump),
+		)
+	}
+
 	if t.SystemTransportArgs.AuthPrivateKey != "" {
 		t.OpenCmd = append(
 			t.OpenCmd,
@@ -98,13 +124,70 @@ func (t *System) buildOpenCmd() {
 	}
 }
 
-// Open opens a standard connection -- typically `ssh`, but users can set the `ExecCommand` to spawn
-// different types of programs such as `docker exec` or `kubectl exec`.
-func (t *System) Open() error {
+// preflightHostKeyCheck dials the target once using the native golang ssh client purely to
+// validate its host key before the ssh subprocess is spawned. This is needed because, unlike the
+// Standard transport, System has no direct visibility into the host key the forked ssh process
+// verifies -- without this check a rejected host key would only surface as an opaque non-zero
+// exit from the ssh subprocess. BaseTransportArgs.HostKeyCallback, when set, overrides the
+// default known_hosts based verifier, same as it does for Standard.
+func (t *System) preflightHostKeyCheck() error {
+	callback := t.BaseTransportArgs.HostKeyCallback
+
+	if callback == nil {
+		var err error
+
+		callback, err = BuildHostKeyCallback(t.SystemTransportArgs.SSHKnownHostsFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	addr := fmt.Sprintf("%s:%d", t.BaseTransportArgs.Host, t.BaseTransportArgs.Port)
+
+	var hostKeyErr error
+
+	_, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKeyErr = callback(hostname, remote, key)
+
+			return hostKeyErr
+		},
+		Timeout: *t.BaseTransportArgs.TimeoutSocket,
+	})
+
+	if hostKeyErr != nil {
+		return hostKeyErr
+	}
+
+	if err != nil && errors.Is(err, ErrKeyVerificationFailed) {
+		return err
+	}
+
+	// any other error (e.g. no auth methods offered/accepted, or the host not being reachable) is
+	// not a host key rejection, so the real ssh subprocess is left to proceed and handle it on its
+	// own
+	return nil
+}
+
+// OpenCtx opens a standard connection -- typically `ssh`, but users can set the `ExecCmd` to
+// spawn different types of programs such as `docker exec` or `kubectl exec` -- tearing the
+// transport back down if ctx is done before it finishes establishing.
+func (t *System) OpenCtx(ctx context.Context) error {
 	if t.OpenCmd == nil {
 		t.buildOpenCmd()
 	}
 
+	if t.SystemTransportArgs.AuthStrictKey &&
+		(t.SystemTransportArgs.SSHKnownHostsFile == "" || t.BaseTransportArgs.HostKeyCallback != nil) {
+		if err := t.preflightHostKeyCheck(); err != nil {
+			logging.LogError(
+				FormatLogMessage(t.BaseTransportArgs, "error", "host key verification failed"),
+			)
+
+			return err
+		}
+	}
+
 	if t.ExecCmd == "" {
 		t.ExecCmd = "ssh"
 	}
@@ -140,17 +223,33 @@ func (t *System) Open() error {
 		return err
 	}
 
+	t.fileObj = fileObj
+
+	if ctx.Err() != nil {
+		_ = t.Close()
+
+		return ctx.Err()
+	}
+
 	logging.LogDebug(
 		FormatLogMessage(t.BaseTransportArgs, "debug", "transport connection to host opened"),
 	)
 
-	t.fileObj = fileObj
+	return nil
+}
 
-	return err
+// Open opens a standard connection -- typically `ssh`, but users can set the `ExecCmd` to spawn
+// different types of programs such as `docker exec` or `kubectl exec`.
+func (t *System) Open() error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.OpenCtx(ctx)
 }
 
-// OpenNetconf opens a netconf connection.
-func (t *System) OpenNetconf() error {
+// OpenNetconfCtx opens a netconf connection, subject to the same ctx-based cancellation as
+// OpenCtx.
+func (t *System) OpenNetconfCtx(ctx context.Context) error {
 	t.buildOpenCmd()
 
 	t.OpenCmd = append(t.OpenCmd,
@@ -159,6 +258,17 @@ func (t *System) OpenNetconf() error {
 		"netconf",
 	)
 
+	if t.SystemTransportArgs.AuthStrictKey &&
+		(t.SystemTransportArgs.SSHKnownHostsFile == "" || t.BaseTransportArgs.HostKeyCallback != nil) {
+		if err := t.preflightHostKeyCheck(); err != nil {
+			logging.LogError(
+				FormatLogMessage(t.BaseTransportArgs, "error", "host key verification failed"),
+			)
+
+			return err
+		}
+	}
+
 	logging.LogDebug(
 		FormatLogMessage(t.BaseTransportArgs,
 			"debug",
@@ -184,6 +294,14 @@ func (t *System) OpenNetconf() error {
 		return err
 	}
 
+	t.fileObj = fileObj
+
+	if ctx.Err() != nil {
+		_ = t.Close()
+
+		return ctx.Err()
+	}
+
 	logging.LogDebug(
 		FormatLogMessage(
 			t.BaseTransportArgs,
@@ -192,9 +310,15 @@ func (t *System) OpenNetconf() error {
 		),
 	)
 
-	t.fileObj = fileObj
+	return nil
+}
 
-	return err
+// OpenNetconf opens a netconf connection.
+func (t *System) OpenNetconf() error {
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
+
+	return t.OpenNetconfCtx(ctx)
 }
 
 // Close closes the transport connection to the device.
@@ -208,72 +332,189 @@ func (t *System) Close() error {
 	return err
 }
 
-func (t *System) read(n int) *transportResult {
+// readNCtx reads n bytes from the transport, returning ErrTransportTimeout once ctx is done. A
+// deadline derived from ctx is pushed onto the underlying *os.File so the in-flight read returns
+// on its own once the deadline passes; when ctx has no deadline (or SetReadDeadline isn't
+// supported), a watcher goroutine closes the pty on ctx.Done() instead to force the read to
+// return, and always exits on its own once ctx fires or the read finishes first.
+func (t *System) readNCtx(ctx context.Context, n int) ([]byte, error) {
+	deadline, hasDeadline := ctx.Deadline()
+
+	deadlineSupported := false
+
+	if hasDeadline {
+		deadlineSupported = t.fileObj.SetReadDeadline(deadline) == nil
+	} else {
+		// clear any deadline left behind by an earlier read
+		_ = t.fileObj.SetReadDeadline(time.Time{})
+	}
+
+	if !deadlineSupported {
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-ctx.Done():
+				_ = t.fileObj.Close()
+			case <-done:
+			}
+		}()
+	}
+
 	b := make([]byte, n)
-	_, err := t.fileObj.Read(b)
+	nRead, err := t.fileObj.Read(b)
 
 	if err != nil {
-		return &transportResult{
-			result: nil,
-			error:  ErrTransportFailure,
+		if ctx.Err() != nil || errors.Is(err, os.ErrDeadlineExceeded) {
+			logging.LogError(
+				FormatLogMessage(t.BaseTransportArgs, "error", "timed out reading from transport"),
+			)
+
+			return nil, ErrTransportTimeout
 		}
-	}
 
-	return &transportResult{
-		result: b,
-		error:  nil,
+		return nil, ErrTransportFailure
 	}
+
+	return b[:nRead], nil
+}
+
+// ReadCtx reads bytes from the transport, subject to ctx-based cancellation.
+func (t *System) ReadCtx(ctx context.Context) ([]byte, error) {
+	return t.readNCtx(ctx, ReadSize)
 }
 
 // Read reads bytes from the transport.
 func (t *System) Read() ([]byte, error) {
-	b, err := transportTimeout(
-		*t.BaseTransportArgs.TimeoutTransport,
-		t.read,
-		ReadSize,
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
 
-	if err != nil {
-		logging.LogError(
-			FormatLogMessage(t.BaseTransportArgs, "error", "timed out reading from transport"),
-		)
-
-		return b, err
-	}
+	return t.ReadCtx(ctx)
+}
 
-	return b, nil
+// ReadNCtx reads n bytes from the transport, subject to ctx-based cancellation.
+func (t *System) ReadNCtx(ctx context.Context, n int) ([]byte, error) {
+	return t.readNCtx(ctx, n)
 }
 
 // ReadN reads N bytes from the transport.
 func (t *System) ReadN(n int) ([]byte, error) {
-	b, err := transportTimeout(
-		*t.BaseTransportArgs.TimeoutTransport,
-		t.read,
-		n,
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
 
-	if err != nil {
-		logging.LogError(
-			FormatLogMessage(t.BaseTransportArgs, "error", "timed out reading from transport"),
-		)
+	return t.ReadNCtx(ctx, n)
+}
 
-		return b, err
+// WriteCtx writes bytes to the transport, subject to ctx-based cancellation.
+func (t *System) WriteCtx(ctx context.Context, channelInput []byte) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = t.fileObj.SetWriteDeadline(deadline)
 	}
 
-	return b, nil
+	_, err := t.fileObj.Write(channelInput)
+
+	return err
 }
 
 // Write writes bytes to the transport.
 func (t *System) Write(channelInput []byte) error {
-	_, err := t.fileObj.Write(channelInput)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), *t.BaseTransportArgs.TimeoutTransport)
+	defer cancel()
 
-	return nil
+	return t.WriteCtx(ctx, channelInput)
 }
 
 // IsAlive indicates if the transport is alive or not.
 func (t *System) IsAlive() bool {
 	return t.fileObj != nil
 }
+
+// scpFlags renders the scp equivalent of the connection flags buildOpenCmd produces for ssh. scp
+// shares most flags with ssh but spells the port flag `-P` instead of `-p`.
+func (t *System) scpFlags() []string {
+	flags := []string{
+		"-P", fmt.Sprintf("%d", t.BaseTransportArgs.Port),
+		"-o", fmt.Sprintf("ConnectTimeout=%d", int(t.BaseTransportArgs.TimeoutSocket.Seconds())),
+		"-o", fmt.Sprintf("ServerAliveInterval=%d", int(t.BaseTransportArgs.TimeoutTransport.Seconds())),
+	}
+
+	if len(t.BaseTransportArgs.ProxyJump) > 0 {
+		flags = append(flags, "-J", buildProxyJumpArg(t.BaseTransportArgs.ProxyJump))
+	}
+
+	if t.SystemTransportArgs.AuthPrivateKey != "" {
+		flags = append(flags, "-i", t.SystemTransportArgs.AuthPrivateKey)
+	}
+
+	if !t.SystemTransportArgs.AuthStrictKey {
+		flags = append(flags, "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null")
+	} else {
+		flags = append(flags, "-o", "StrictHostKeyChecking=yes")
+
+		if t.SystemTransportArgs.SSHKnownHostsFile != "" {
+			flags = append(
+				flags,
+				"-o",
+				fmt.Sprintf("UserKnownHostsFile=%s", t.SystemTransportArgs.SSHKnownHostsFile),
+			)
+		}
+	}
+
+	if t.SystemTransportArgs.SSHConfigFile != "" {
+		flags = append(flags, "-F", t.SystemTransportArgs.SSHConfigFile)
+	} else {
+		flags = append(flags, "-F", "/dev/null")
+	}
+
+	return flags
+}
+
+// remoteSpec renders the scp "[user@]host:path" form of remotePath.
+func (t *System) remoteSpec(remotePath string) string {
+	if t.BaseTransportArgs.AuthUsername != "" {
+		return fmt.Sprintf("%s@%s:%s", t.BaseTransportArgs.AuthUsername, t.BaseTransportArgs.Host, remotePath)
+	}
+
+	return fmt.Sprintf("%s:%s", t.BaseTransportArgs.Host, remotePath)
+}
+
+// shellQuote wraps s in single quotes for safe interpolation into a remote shell command,
+// escaping any single quotes already present in s.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// PutFile copies the local file at localPath to remotePath on the device by shelling out to scp
+// with the same connection flags buildOpenCmd produces for ssh, then chmods the remote file to
+// mode over a follow-up ssh exec.
+func (t *System) PutFile(localPath, remotePath string, mode os.FileMode) error {
+	if t.OpenCmd == nil {
+		t.buildOpenCmd()
+	}
+
+	scpArgs := append(t.scpFlags(), localPath, t.remoteSpec(remotePath))
+
+	if err := exec.Command("scp", scpArgs...).Run(); err != nil { //nolint:gosec
+		return err
+	}
+
+	chmodArgs := append(
+		append([]string{}, t.OpenCmd...),
+		fmt.Sprintf("chmod %o %s", mode.Perm(), shellQuote(remotePath)),
+	)
+
+	return exec.Command("ssh", chmodArgs...).Run() //nolint:gosec
+}
+
+// GetFile copies remotePath on the device to the local file at localPath by shelling out to scp
+// with the same connection flags buildOpenCmd produces for ssh.
+func (t *System) GetFile(remotePath, localPath string) error {
+	if t.OpenCmd == nil {
+		t.buildOpenCmd()
+	}
+
+	scpArgs := append(t.scpFlags(), t.remoteSpec(remotePath), localPath)
+
+	return exec.Command("scp", scpArgs...).Run() //nolint:gosec
+}
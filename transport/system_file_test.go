@@ -0,0 +1,125 @@
+// +build !windows
+
+package transport
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestSystem(strictKey bool, knownHostsFile string) *System {
+	socketTimeout := 5 * time.Second
+	transportTimeout := 10 * time.Second
+
+	return &System{
+		BaseTransportArgs: &BaseTransportArgs{
+			Host:             "router1",
+			Port:             22,
+			AuthUsername:     "admin",
+			TimeoutSocket:    &socketTimeout,
+			TimeoutTransport: &transportTimeout,
+		},
+		SystemTransportArgs: &SystemTransportArgs{
+			AuthStrictKey:     strictKey,
+			SSHKnownHostsFile: knownHostsFile,
+		},
+	}
+}
+
+func TestScpFlagsIncludesTimeoutsAndPort(t *testing.T) {
+	sys := newTestSystem(true, "/home/admin/.ssh/known_hosts")
+
+	flags := sys.scpFlags()
+
+	want := []string{
+		"-P", "22",
+		"-o", "ConnectTimeout=5",
+		"-o", "ServerAliveInterval=10",
+		"-o", "StrictHostKeyChecking=yes",
+		"-o", "UserKnownHostsFile=/home/admin/.ssh/known_hosts",
+		"-F", "/dev/null",
+	}
+
+	if len(flags) != len(want) {
+		t.Fatalf("expected %d flags, got %d: %v", len(want), len(flags), flags)
+	}
+
+	for i := range want {
+		if flags[i] != want[i] {
+			t.Fatalf("flag %d: expected %q, got %q (full: %v)", i, want[i], flags[i], flags)
+		}
+	}
+}
+
+func TestScpFlagsInsecureDropsStrictChecking(t *testing.T) {
+	sys := newTestSystem(false, "")
+
+	flags := sys.scpFlags()
+
+	want := []string{
+		"-P", "22",
+		"-o", "ConnectTimeout=5",
+		"-o", "ServerAliveInterval=10",
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-F", "/dev/null",
+	}
+
+	if len(flags) != len(want) {
+		t.Fatalf("expected %d flags, got %d: %v", len(want), len(flags), flags)
+	}
+
+	for i := range want {
+		if flags[i] != want[i] {
+			t.Fatalf("flag %d: expected %q, got %q (full: %v)", i, want[i], flags[i], flags)
+		}
+	}
+}
+
+func TestRemoteSpecWithUsername(t *testing.T) {
+	sys := newTestSystem(true, "")
+
+	got := sys.remoteSpec("/etc/config")
+	want := "admin@router1:/etc/config"
+
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRemoteSpecWithoutUsername(t *testing.T) {
+	sys := newTestSystem(true, "")
+	sys.BaseTransportArgs.AuthUsername = ""
+
+	got := sys.remoteSpec("/etc/config")
+	want := "router1:/etc/config"
+
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestShellQuoteEscapesSingleQuotes(t *testing.T) {
+	got := shellQuote("/tmp/a'b")
+	want := `'/tmp/a'\''b'`
+
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestStandardPutFileReturnsErrTransportNotOpenWhenUnopened(t *testing.T) {
+	var std Standard
+
+	if err := std.PutFile("local", "remote", 0o644); err != ErrTransportNotOpen {
+		t.Fatalf("expected ErrTransportNotOpen, got %v", err)
+	}
+}
+
+func TestStandardGetFileReturnsErrTransportNotOpenWhenUnopened(t *testing.T) {
+	var std Standard
+
+	if err := std.GetFile("remote", "local"); err != ErrTransportNotOpen {
+		t.Fatalf("expected ErrTransportNotOpen, got %v", err)
+	}
+}
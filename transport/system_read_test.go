@@ -0,0 +1,100 @@
+// +build !windows
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestSystemReadNCtxTimesOutWithoutLeakingReader proves that a canceled readNCtx does not leave
+// the watcher goroutine racing a subsequent read: the first read is expected to time out (via
+// SetReadDeadline, which os.Pipe supports on this platform) while leaving fileObj open, and a
+// second read on the same fileObj must still succeed with the expected bytes.
+func TestSystemReadNCtxTimesOutWithoutLeakingReader(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed creating pipe: %v", err)
+	}
+	defer w.Close()
+	defer r.Close()
+
+	socketTimeout := time.Second
+	transportTimeout := time.Second
+
+	sys := &System{
+		BaseTransportArgs: &BaseTransportArgs{
+			TimeoutSocket:    &socketTimeout,
+			TimeoutTransport: &transportTimeout,
+		},
+		fileObj: r,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := sys.readNCtx(ctx, ReadSize); !errors.Is(err, ErrTransportTimeout) {
+		t.Fatalf("expected ErrTransportTimeout, got %v", err)
+	}
+
+	go func() {
+		_, _ = w.Write([]byte("hello"))
+	}()
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), time.Second)
+	defer cancel2()
+
+	b, err := sys.readNCtx(ctx2, ReadSize)
+	if err != nil {
+		t.Fatalf("unexpected error on second read: %v", err)
+	}
+
+	if string(b) != "hello" {
+		t.Fatalf("expected to read %q, got %q", "hello", string(b))
+	}
+}
+
+// TestSystemReadNCtxCanceledWithoutDeadlineStillInterruptsRead proves that a ctx with no deadline
+// (e.g. context.WithCancel) still unblocks an in-flight read via the watcher goroutine, rather
+// than only being honored when ctx carries a deadline.
+func TestSystemReadNCtxCanceledWithoutDeadlineStillInterruptsRead(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed creating pipe: %v", err)
+	}
+	defer w.Close()
+
+	socketTimeout := time.Second
+	transportTimeout := time.Second
+
+	sys := &System{
+		BaseTransportArgs: &BaseTransportArgs{
+			TimeoutSocket:    &socketTimeout,
+			TimeoutTransport: &transportTimeout,
+		},
+		fileObj: r,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan struct{})
+
+	go func() {
+		_, _ = sys.readNCtx(ctx, ReadSize)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected canceled readNCtx with no deadline to return promptly")
+	}
+}